@@ -0,0 +1,28 @@
+package frodo
+
+import "testing"
+
+// TestKeyGenEncDecAES640 checks that KeyGen/Enc/Dec round-trip a message
+// under the AES-expanded Frodo-640 parameter set.
+func TestKeyGenEncDecAES640(t *testing.T) {
+
+	param := Frodo640AES()
+
+	pk, sk, err := param.KeyGen()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	message := make([]byte, param.l)
+	ct, err := param.Enc(message, pk)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got := param.Dec(ct, sk)
+	for i := range message {
+		if got[i] != message[i] {
+			t.Fatalf("Dec did not recover the original message: got %v, want %v", got, message)
+		}
+	}
+}
@@ -0,0 +1,225 @@
+package frodo
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrMissingParameters is returned by MarshalBinary/UnmarshalBinary when the
+// receiver has no associated Parameters to size and pack/unpack against.
+var ErrMissingParameters = errors.New("frodo: key or ciphertext has no associated parameters")
+
+// LengthError reports that an encoded public key, secret key or ciphertext
+// did not have the byte length its parameter set expects.
+type LengthError struct {
+	What     string // what was being decoded, e.g. "public key"
+	Expected int
+	Got      int
+}
+
+func (e *LengthError) Error() string {
+	return fmt.Sprintf("frodo: invalid %s length: expected %d bytes, got %d", e.What, e.Expected, e.Got)
+}
+
+// NewPublicKey returns a PublicKey associated with param but with no key
+// material set, ready for UnmarshalBinary.
+func (param *Parameters) NewPublicKey() *PublicKey {
+	return &PublicKey{param: param}
+}
+
+// NewSecretKey returns a SecretKey associated with param but with no key
+// material set, ready for UnmarshalBinary.
+func (param *Parameters) NewSecretKey() *SecretKey {
+	return &SecretKey{param: param}
+}
+
+// NewCipherText returns a CipherText associated with param but with no
+// ciphertext material set, ready for UnmarshalBinary.
+func (param *Parameters) NewCipherText() *CipherText {
+	return &CipherText{param: param}
+}
+
+// EncodePublicKey encodes pk as seedA‖Pack(B), the public key wire format
+// defined by the FrodoKEM specification.
+func (param *Parameters) EncodePublicKey(pk *PublicKey) []byte {
+	return param.pkBytes(pk)
+}
+
+// DecodePublicKey parses the seedA‖Pack(B) encoding produced by EncodePublicKey.
+func (param *Parameters) DecodePublicKey(b []byte) (*PublicKey, error) {
+
+	seedLen, bLen := param.lseedA/8, param.D*param.no*param.n/8
+	if len(b) != seedLen+bLen {
+		return nil, &LengthError{What: "public key", Expected: seedLen + bLen, Got: len(b)}
+	}
+
+	pk := &PublicKey{param: param}
+	pk.seedA = append([]byte{}, b[:seedLen]...)
+	pk.B = param.Unpack(b[seedLen:], param.no, param.n)
+
+	return pk, nil
+}
+
+// EncodeSecretKey encodes sk as s‖seedA‖Pack(B)‖Pack(Sᵀ)‖pkh, the CCA KEM
+// secret key wire format defined by the FrodoKEM specification.
+func (param *Parameters) EncodeSecretKey(sk *SecretKey) []byte {
+
+	b := append([]byte{}, sk.s...)
+	b = append(b, param.pkBytes(sk.pk)...)
+	b = append(b, param.Pack(transpose(sk.S))...)
+	b = append(b, sk.pkh...)
+
+	return b
+}
+
+// DecodeSecretKey parses the s‖seedA‖Pack(B)‖Pack(Sᵀ)‖pkh encoding produced
+// by EncodeSecretKey.
+func (param *Parameters) DecodeSecretKey(b []byte) (*SecretKey, error) {
+
+	sLen, pkLen := param.lens/8, param.lseedA/8+param.D*param.no*param.n/8
+	sTLen, pkhLen := param.D*param.n*param.no/8, param.lenpkh/8
+
+	expected := sLen + pkLen + sTLen + pkhLen
+	if len(b) != expected {
+		return nil, &LengthError{What: "secret key", Expected: expected, Got: len(b)}
+	}
+
+	off := 0
+	s := append([]byte{}, b[off:off+sLen]...)
+	off += sLen
+
+	pk, err := param.DecodePublicKey(b[off : off+pkLen])
+	if err != nil {
+		return nil, err
+	}
+	off += pkLen
+
+	sT := param.Unpack(b[off:off+sTLen], param.n, param.no)
+	off += sTLen
+
+	pkh := append([]byte{}, b[off:off+pkhLen]...)
+
+	return &SecretKey{param: param, pk: pk, s: s, pkh: pkh, S: transpose(sT)}, nil
+}
+
+// EncodeCipherText encodes ct as Pack(C1)‖Pack(C2).
+func (param *Parameters) EncodeCipherText(ct *CipherText) []byte {
+
+	b := append([]byte{}, param.Pack(ct.C1)...)
+	b = append(b, param.Pack(ct.C2)...)
+
+	return b
+}
+
+// DecodeCipherText parses the Pack(C1)‖Pack(C2) encoding produced by
+// EncodeCipherText.
+func (param *Parameters) DecodeCipherText(b []byte) (*CipherText, error) {
+
+	c1Len, c2Len := param.D*param.m*param.no/8, param.D*param.m*param.n/8
+	if len(b) != c1Len+c2Len {
+		return nil, &LengthError{What: "ciphertext", Expected: c1Len + c2Len, Got: len(b)}
+	}
+
+	ct := &CipherText{param: param}
+	ct.C1 = param.Unpack(b[:c1Len], param.m, param.no)
+	ct.C2 = param.Unpack(b[c1Len:], param.m, param.n)
+
+	return ct, nil
+}
+
+// MarshalBinary encodes pk per EncodePublicKey.
+func (pk *PublicKey) MarshalBinary() ([]byte, error) {
+
+	if pk.param == nil {
+		return nil, ErrMissingParameters
+	}
+	return pk.param.EncodePublicKey(pk), nil
+}
+
+// UnmarshalBinary decodes data per DecodePublicKey. pk.param must already be
+// set, e.g. by constructing pk with Parameters.NewPublicKey.
+func (pk *PublicKey) UnmarshalBinary(data []byte) error {
+
+	if pk.param == nil {
+		return ErrMissingParameters
+	}
+
+	decoded, err := pk.param.DecodePublicKey(data)
+	if err != nil {
+		return err
+	}
+	*pk = *decoded
+
+	return nil
+}
+
+// MarshalBinary encodes sk per EncodeSecretKey.
+func (sk *SecretKey) MarshalBinary() ([]byte, error) {
+
+	if sk.param == nil {
+		return nil, ErrMissingParameters
+	}
+	return sk.param.EncodeSecretKey(sk), nil
+}
+
+// UnmarshalBinary decodes data per DecodeSecretKey. sk.param must already be
+// set, e.g. by constructing sk with Parameters.NewSecretKey.
+func (sk *SecretKey) UnmarshalBinary(data []byte) error {
+
+	if sk.param == nil {
+		return ErrMissingParameters
+	}
+
+	decoded, err := sk.param.DecodeSecretKey(data)
+	if err != nil {
+		return err
+	}
+	*sk = *decoded
+
+	return nil
+}
+
+// MarshalBinary encodes ct per EncodeCipherText.
+func (ct *CipherText) MarshalBinary() ([]byte, error) {
+
+	if ct.param == nil {
+		return nil, ErrMissingParameters
+	}
+	return ct.param.EncodeCipherText(ct), nil
+}
+
+// UnmarshalBinary decodes data per DecodeCipherText. ct.param must already be
+// set, e.g. by constructing ct with Parameters.NewCipherText.
+func (ct *CipherText) UnmarshalBinary(data []byte) error {
+
+	if ct.param == nil {
+		return ErrMissingParameters
+	}
+
+	decoded, err := ct.param.DecodeCipherText(data)
+	if err != nil {
+		return err
+	}
+	*ct = *decoded
+
+	return nil
+}
+
+// transpose returns the transpose of M.
+func transpose(M [][]uint16) [][]uint16 {
+
+	if len(M) == 0 {
+		return nil
+	}
+
+	n1, n2 := len(M), len(M[0])
+	T := make([][]uint16, n2)
+	for j := 0; j < n2; j++ {
+		T[j] = make([]uint16, n1)
+		for i := 0; i < n1; i++ {
+			T[j][i] = M[i][j]
+		}
+	}
+
+	return T
+}
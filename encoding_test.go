@@ -0,0 +1,71 @@
+package frodo
+
+import (
+	"reflect"
+	"testing"
+)
+
+func testMarshalRoundTrip(t *testing.T, param *Parameters) {
+
+	pk, sk, err := param.KeyGen()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	message := make([]byte, param.lenmu/8)
+	ct, err := param.Enc(message, pk)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	pkBytes, err := pk.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+	gotPk := param.NewPublicKey()
+	if err := gotPk.UnmarshalBinary(pkBytes); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(pk.B, gotPk.B) || !reflect.DeepEqual(pk.seedA, gotPk.seedA) {
+		t.Fatal("PublicKey round trip did not reproduce the original key")
+	}
+
+	skBytes, err := sk.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+	gotSk := param.NewSecretKey()
+	if err := gotSk.UnmarshalBinary(skBytes); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(sk.S, gotSk.S) || !reflect.DeepEqual(sk.s, gotSk.s) || !reflect.DeepEqual(sk.pkh, gotSk.pkh) {
+		t.Fatal("SecretKey round trip did not reproduce the original key")
+	}
+
+	ctBytes, err := ct.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+	gotCt := param.NewCipherText()
+	if err := gotCt.UnmarshalBinary(ctBytes); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(ct.C1, gotCt.C1) || !reflect.DeepEqual(ct.C2, gotCt.C2) {
+		t.Fatal("CipherText round trip did not reproduce the original ciphertext")
+	}
+}
+
+// TestMarshalRoundTrip640 checks that MarshalBinary/UnmarshalBinary on
+// PublicKey, SecretKey and CipherText recover the original value for
+// Frodo-640.
+func TestMarshalRoundTrip640(t *testing.T) { testMarshalRoundTrip(t, Frodo640()) }
+
+// TestMarshalRoundTrip976 checks that MarshalBinary/UnmarshalBinary on
+// PublicKey, SecretKey and CipherText recover the original value for
+// Frodo-976.
+func TestMarshalRoundTrip976(t *testing.T) { testMarshalRoundTrip(t, Frodo976()) }
+
+// TestMarshalRoundTrip1344 checks that MarshalBinary/UnmarshalBinary on
+// PublicKey, SecretKey and CipherText recover the original value for
+// Frodo-1344.
+func TestMarshalRoundTrip1344(t *testing.T) { testMarshalRoundTrip(t, Frodo1344()) }
@@ -1,6 +1,12 @@
 package frodo
 
-import "encoding/binary"
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"encoding/binary"
+
+	"golang.org/x/crypto/sha3"
+)
 
 // Frodo interface
 type Frodo interface {
@@ -9,6 +15,7 @@ type Frodo interface {
 	Pack(C [][]uint16) []byte                     // Pack packs a matrix into a bit string
 	Unpack(b []byte, n1, n2 int) [][]uint16       // Unpack unpacks a bit string into a matrix n1-by-n2
 	Gen(seed []byte) [][]uint16                   // Gen returns a pseudorandom matrix using SHAKE128
+	GenAES(seed []byte) [][]uint16                // GenAES returns a pseudorandom matrix using AES-128 in ECB mode
 	Sample(t uint16) uint16                       // Sample returns a sample e from the distribution χ
 	SampleMatrix(r []byte, n1, n2 int) [][]uint16 // SampleMatrix sample the n1-by-n2 matrix entry
 }
@@ -21,9 +28,27 @@ type Parameters struct {
 	m, n   int    // integer matrix dimensions with
 	B      int    // the number of bits encoded in each matrix entry
 	l      int    // B·m·n, the length of bit strings that are encoded as m-by-n matrices
-	lseedA int    // the byte length of public matrix A
+	lseedA int    // the bit length of seedA, the seed used to expand public matrix A (128 for every parameter set, so it also works as an AES-128 key)
+	useAES bool   // whether the public matrix A is expanded with AES-128 instead of SHAKE128
+
+	lseedSE int // the bit length of seedSE, the seed used to sample S/E (and S1/E1/E2)
+	lenmu   int // the bit length of μ, the random message encapsulated by the FO transform
+	lenss   int // the bit length of the shared secret ss produced by Encaps/Decaps
+	lenpkh  int // the bit length of pkh = SHAKE(pk)
+	lens    int // the bit length of s, the per-key secret used for implicit/explicit rejection
+
+	lenX int      // the bit length of the raw randomness consumed per sample by Sample
+	tchi []uint16 // Tχ, the CDT defining the error distribution χ
 }
 
+// Tχ, the cumulative distribution tables defining the error distribution χ
+// for each parameter set, as specified by the FrodoKEM submission.
+var (
+	tchi640  = []uint16{4643, 13363, 20579, 25843, 29227, 31145, 32103, 32525, 32689, 32745, 32762, 32766, 32767}
+	tchi976  = []uint16{5638, 15915, 23689, 28571, 31116, 32217, 32613, 32731, 32760, 32766, 32767}
+	tchi1344 = []uint16{9142, 23462, 30338, 32361, 32725, 32765, 32767}
+)
+
 // Frodo640 returns Parameters struct no.640
 func Frodo640() *Parameters {
 
@@ -35,9 +60,18 @@ func Frodo640() *Parameters {
 	param.B = 2
 	param.m = 8
 	param.n = 8
-	param.lseedA = 16
+	param.lseedA = 128
 	param.l = 16
 
+	param.lseedSE = 128
+	param.lenmu = 128
+	param.lenss = 128
+	param.lenpkh = 128
+	param.lens = 128
+
+	param.lenX = 16
+	param.tchi = tchi640
+
 	return param
 }
 
@@ -52,9 +86,18 @@ func Frodo976() *Parameters {
 	param.B = 3
 	param.m = 8
 	param.n = 8
-	param.lseedA = 16
+	param.lseedA = 128
 	param.l = 24
 
+	param.lseedSE = 192
+	param.lenmu = 192
+	param.lenss = 192
+	param.lenpkh = 192
+	param.lens = 192
+
+	param.lenX = 16
+	param.tchi = tchi976
+
 	return param
 }
 
@@ -69,12 +112,82 @@ func Frodo1344() *Parameters {
 	param.B = 4
 	param.m = 8
 	param.n = 8
-	param.lseedA = 16
+	param.lseedA = 128
 	param.l = 32
 
+	param.lseedSE = 256
+	param.lenmu = 256
+	param.lenss = 256
+	param.lenpkh = 256
+	param.lens = 256
+
+	param.lenX = 16
+	param.tchi = tchi1344
+
+	return param
+}
+
+// Frodo640AES returns Parameters struct no.640 with A expanded via AES-128
+func Frodo640AES() *Parameters {
+
+	param := Frodo640()
+	param.useAES = true
+
+	return param
+}
+
+// Frodo976AES returns Parameters struct no.976 with A expanded via AES-128
+func Frodo976AES() *Parameters {
+
+	param := Frodo976()
+	param.useAES = true
+
 	return param
 }
 
+// Frodo1344AES returns Parameters struct no.1344 with A expanded via AES-128
+func Frodo1344AES() *Parameters {
+
+	param := Frodo1344()
+	param.useAES = true
+
+	return param
+}
+
+// ec implements ec(k) := k·2^(D−B), encoding a B-bit integer as an element of Zq.
+func (param *Parameters) ec(k uint16) uint16 {
+	return (k << uint(param.D-param.B)) & param.q
+}
+
+// dc implements dc(c) := ⌊c·2^B/q⌉ mod 2^B, decoding an element of Zq back
+// into a B-bit integer by rounding to the nearest multiple of 2^(D−B).
+func (param *Parameters) dc(c uint16) uint16 {
+
+	shift := uint(param.D - param.B)
+	rounded := (c + (uint16(1) << (shift - 1))) >> shift
+
+	return rounded & ((uint16(1) << uint(param.B)) - 1)
+}
+
+// shake returns outLen bytes of SHAKE128 (Frodo-640) or SHAKE256 (Frodo-976,
+// Frodo-1344) output over b, the extendable-output hash used throughout the
+// scheme to expand seeds and randomness.
+func (param *Parameters) shake(b []byte, outLen int) []byte {
+
+	var h sha3.ShakeHash
+	if param.no == 640 {
+		h = sha3.NewShake128()
+	} else {
+		h = sha3.NewShake256()
+	}
+
+	h.Write(b)
+	out := make([]byte, outLen)
+	h.Read(out)
+
+	return out
+}
+
 // Encode encodes an integer 0 ≤ k < 2^B as an element in Zq
 // by multiplying it by q/2B = 2^(D−B): ec(k) := k·q/2^B
 func (param *Parameters) Encode(k []byte) [][]uint16 {
@@ -154,21 +267,90 @@ func (param *Parameters) Unpack(b []byte, n1, n2 int) [][]uint16 {
 func (param *Parameters) Gen(seed []byte) [][]uint16 {
 
 	A := make([][]uint16, param.no)
-	for i := uint16(0); i < uint16(param.no); i++ {
+	for i := 0; i < param.no; i++ {
+		A[i] = param.genShakeRow(seed, i)
+	}
 
-		b := []byte{byte(i >> 8), byte(i)}
-		b = append(b, seed...)
-		shakeStr := param.shake(b, param.no*2)
+	return A
+}
 
-		A[i] = make([]uint16, param.no)
-		for j := 0; j < param.no; j++ {
-			A[i][j] = ((uint16(shakeStr[j*2]) << 8) | uint16(shakeStr[i*2+1])) & param.q
-		}
+// genShakeRow computes row i of A (param.no entries wide) via SHAKE, so A can
+// be streamed row-by-row instead of materialized in full.
+func (param *Parameters) genShakeRow(seed []byte, i int) []uint16 {
+
+	b := []byte{byte(uint16(i) >> 8), byte(uint16(i))}
+	b = append(b, seed...)
+	shakeStr := param.shake(b, param.no*2)
+
+	row := make([]uint16, param.no)
+	for j := 0; j < param.no; j++ {
+		row[j] = ((uint16(shakeStr[j*2]) << 8) | uint16(shakeStr[j*2+1])) & param.q
+	}
+
+	return row
+}
+
+// GenAES returns a pseudorandom matrix using AES-128 in ECB mode with seedA as
+// the fixed key, encrypting 16-byte blocks (i, j, 0, ..., 0) for j in strides of 8
+// and splitting each 128-bit output block into eight 16-bit little-endian entries.
+func (param *Parameters) GenAES(seed []byte) [][]uint16 {
+
+	cipher, err := aes.NewCipher(seed)
+	if err != nil {
+		panic(err)
+	}
+
+	A := make([][]uint16, param.no)
+	for i := 0; i < param.no; i++ {
+		A[i] = genAESRow(cipher, param.no, param.q, i)
 	}
 
 	return A
 }
 
+// genAESRow computes row i of A (no entries wide) by AES-ECB-encrypting
+// 16-byte blocks (i, j, 0, ..., 0) for j in strides of 8 under cipher, so A
+// can be streamed row-by-row instead of materialized in full.
+func genAESRow(cipher cipher.Block, no int, q uint16, i int) []uint16 {
+
+	row := make([]uint16, no)
+	block, out := make([]byte, aes.BlockSize), make([]byte, aes.BlockSize)
+	for j := 0; j < no; j += 8 {
+
+		binary.LittleEndian.PutUint16(block[0:], uint16(i))
+		binary.LittleEndian.PutUint16(block[2:], uint16(j))
+		for k := 4; k < aes.BlockSize; k++ {
+			block[k] = 0
+		}
+
+		cipher.Encrypt(out, block)
+		for k := 0; k < 8; k++ {
+			row[j+k] = binary.LittleEndian.Uint16(out[2*k:]) & q
+		}
+	}
+
+	return row
+}
+
+// Sample returns a sample e from the distribution χ defined by param.tchi.
+// t is treated as a lenX-bit unsigned integer: its low bit is the sign s,
+// the remaining bits are the magnitude, which is compared against every
+// entry of the CDT in constant time to obtain e = Σ (magnitude > Tχ[z]).
+// The result is the two's-complement conditional negation ((-s) XOR e) + s,
+// reduced mod q.
+func (param *Parameters) Sample(t uint16) uint16 {
+
+	s := t & 1
+	t >>= 1
+
+	e := uint16(0)
+	for z := 0; z < len(param.tchi)-1; z++ {
+		e += uint16((uint32(param.tchi[z]) - uint32(t)) >> 31)
+	}
+
+	return (((-s) ^ e) + s) & param.q
+}
+
 // SampleMatrix sample the n1-by-n2 matrix entry
 func (param *Parameters) SampleMatrix(r []byte, n1, n2 int) [][]uint16 {
 
@@ -176,14 +358,8 @@ func (param *Parameters) SampleMatrix(r []byte, n1, n2 int) [][]uint16 {
 	for i := 0; i < n1; i++ {
 		E[i] = make([]uint16, n2)
 		for j := 0; j < n2; j++ {
-			t, d := binary.LittleEndian.Uint16(r[2*(i*n2+j):]), uint32(0)
-			r := (uint32(t) << 8) + uint32(t)
-			for j := uint(0); j < 8; j++ {
-				d += (r << j) & 0x01010101
-			}
-			a := ((d >> 8) & 0xff) + (d & 0xff)
-			b := (d >> 24) + ((d >> 16) & 0xff)
-			E[i][j] = 0xfffd - uint16(a) + uint16(b)
+			t := binary.LittleEndian.Uint16(r[2*(i*n2+j):])
+			E[i][j] = param.Sample(t)
 		}
 	}
 
@@ -0,0 +1,49 @@
+package frodo
+
+import (
+	"encoding/binary"
+	"math"
+	"testing"
+)
+
+// testSampleBounded checks that Sample, driven across its full lenX-bit input
+// domain, stays within the bound implied by its CDT and is centered near
+// zero, as expected of the error distribution χ.
+func testSampleBounded(t *testing.T, param *Parameters) {
+
+	bound := int32(len(param.tchi) - 1)
+	modulus := int32(param.q) + 1 // param.q is the mask 2^D − 1
+
+	var sum float64
+	var count int
+	buf := make([]byte, 2)
+	for t16 := 0; t16 < 1<<16; t16++ {
+		binary.LittleEndian.PutUint16(buf, uint16(t16))
+		e := int32(param.Sample(binary.LittleEndian.Uint16(buf)))
+
+		signed := e
+		if signed > modulus/2 {
+			signed -= modulus
+		}
+
+		if signed > bound || signed < -bound {
+			t.Fatalf("Sample(%d) = %d, outside expected bound ±%d", t16, signed, bound)
+		}
+
+		sum += float64(signed)
+		count++
+	}
+
+	if mean := sum / float64(count); math.Abs(mean) > float64(bound)/4 {
+		t.Fatalf("Sample output not centered near zero: mean = %f", mean)
+	}
+}
+
+// TestSampleBounded640 checks Sample's output distribution for Frodo-640.
+func TestSampleBounded640(t *testing.T) { testSampleBounded(t, Frodo640()) }
+
+// TestSampleBounded976 checks Sample's output distribution for Frodo-976.
+func TestSampleBounded976(t *testing.T) { testSampleBounded(t, Frodo976()) }
+
+// TestSampleBounded1344 checks Sample's output distribution for Frodo-1344.
+func TestSampleBounded1344(t *testing.T) { testSampleBounded(t, Frodo1344()) }
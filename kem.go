@@ -0,0 +1,89 @@
+package frodo
+
+import (
+	"crypto/rand"
+	"crypto/subtle"
+)
+
+// KEM interface exposes the IND-CCA secure key encapsulation mechanism built
+// on top of the CPA-secure PKE via the Fujisaki–Okamoto transform with
+// explicit rejection.
+type KEM interface {
+	Encaps(pk *PublicKey) (ct *CipherText, ss []byte, err error) // encapsulate a fresh shared secret under pk
+	Decaps(ct *CipherText, sk *SecretKey) (ss []byte, err error) // recover the shared secret encapsulated in ct
+}
+
+// KeyGenKEM generates a KEM key pair. KeyGen already populates the secret s
+// and public key hash pkh that the FO transform needs, so this is a thin
+// alias kept for callers that think in terms of the KEM rather than the PKE.
+func (param *Parameters) KeyGenKEM() (pk *PublicKey, sk *SecretKey, err error) {
+	return param.KeyGen()
+}
+
+// Encaps samples a random μ, derives (seedSE‖k) = SHAKE(pkh‖μ), deterministically
+// runs the PKE encryption of μ under seedSE, and returns ss = SHAKE(C1‖C2‖k).
+func (param *Parameters) Encaps(pk *PublicKey) (ct *CipherText, ss []byte, err error) {
+
+	mu := make([]byte, param.lenmu/8)
+	if _, err = rand.Read(mu); err != nil {
+		return nil, nil, err
+	}
+
+	pkh := param.shake(param.pkBytes(pk), param.lenpkh/8)
+	seedSE, k := param.expandSeedSEAndK(pkh, mu)
+
+	ct, err = param.encWithSeed(mu, pk, seedSE)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	ss = param.sharedSecret(ct, k)
+
+	return ct, ss, nil
+}
+
+// Decaps decrypts ct to recover μ', re-derives (seedSE'‖k'), re-encrypts
+// under seedSE' and, in constant time, returns SHAKE(C1‖C2‖k') if the
+// re-encryption matches ct or SHAKE(C1‖C2‖s) otherwise.
+func (param *Parameters) Decaps(ct *CipherText, sk *SecretKey) (ss []byte, err error) {
+
+	mu := param.Dec(ct, sk)
+
+	seedSE, k := param.expandSeedSEAndK(sk.pkh, mu)
+
+	ctPrime, err := param.encWithSeed(mu, sk.pk, seedSE)
+	if err != nil {
+		return nil, err
+	}
+
+	match := subtle.ConstantTimeCompare(param.Pack(ct.C1), param.Pack(ctPrime.C1)) &
+		subtle.ConstantTimeCompare(param.Pack(ct.C2), param.Pack(ctPrime.C2))
+
+	kOrS := make([]byte, len(k))
+	subtle.ConstantTimeCopy(1-match, kOrS, sk.s)
+	subtle.ConstantTimeCopy(match, kOrS, k)
+
+	return param.sharedSecret(ct, kOrS), nil
+}
+
+// expandSeedSEAndK derives (seedSE‖k) = SHAKE(pkh‖μ), returning seedSE ready
+// to feed encWithSeed (prefixed with the 0x96 domain-separation byte used by
+// Enc) together with k.
+func (param *Parameters) expandSeedSEAndK(pkh, mu []byte) (seedSE, k []byte) {
+
+	out := param.shake(append(append([]byte{}, pkh...), mu...), param.lseedSE/8+param.lenss/8)
+
+	seedSE = append([]byte{0x96}, out[:param.lseedSE/8]...)
+	k = out[param.lseedSE/8:]
+
+	return seedSE, k
+}
+
+// sharedSecret returns ss = SHAKE(Pack(C1)‖Pack(C2)‖k, lenss).
+func (param *Parameters) sharedSecret(ct *CipherText, k []byte) []byte {
+
+	b := append(param.Pack(ct.C1), param.Pack(ct.C2)...)
+	b = append(b, k...)
+
+	return param.shake(b, param.lenss/8)
+}
@@ -0,0 +1,93 @@
+package frodo
+
+import "testing"
+
+func testEncapsDecapsAgree(t *testing.T, param *Parameters) {
+
+	pk, sk, err := param.KeyGenKEM()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ct, ss, err := param.Encaps(pk)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := param.Decaps(ct, sk)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if string(ss) != string(got) {
+		t.Fatal("Decaps did not recover the shared secret produced by Encaps")
+	}
+}
+
+// testDecapsRejectsTamperedCiphertext checks the explicit-rejection branch of
+// the FO transform: if C1/C2 are tampered with before Decaps, the re-encrypted
+// ciphertext no longer matches, so Decaps must fall back to sk.s instead of
+// the honest k, returning a shared secret that differs from the honest one
+// but is still deterministic for a given (ciphertext, key) pair.
+func testDecapsRejectsTamperedCiphertext(t *testing.T, param *Parameters) {
+
+	pk, sk, err := param.KeyGenKEM()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ct, honestSS, err := param.Encaps(pk)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tamperedC2 := append([]uint16{}, ct.C2[0]...)
+	tamperedC2[0]++ // flip a single Zq entry of C2
+
+	tampered := &CipherText{param: param, C1: ct.C1, C2: append([][]uint16{tamperedC2}, ct.C2[1:]...)}
+
+	rejectedSS, err := param.Decaps(tampered, sk)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if string(rejectedSS) == string(honestSS) {
+		t.Fatal("Decaps accepted a tampered ciphertext instead of rejecting it")
+	}
+
+	again, err := param.Decaps(tampered, sk)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if string(again) != string(rejectedSS) {
+		t.Fatal("Decaps rejection is not deterministic for the same tampered ciphertext and key")
+	}
+}
+
+// TestDecapsRejectsTamperedCiphertext640 exercises explicit rejection for Frodo-640.
+func TestDecapsRejectsTamperedCiphertext640(t *testing.T) {
+	testDecapsRejectsTamperedCiphertext(t, Frodo640())
+}
+
+// TestDecapsRejectsTamperedCiphertext976 exercises explicit rejection for Frodo-976.
+func TestDecapsRejectsTamperedCiphertext976(t *testing.T) {
+	testDecapsRejectsTamperedCiphertext(t, Frodo976())
+}
+
+// TestDecapsRejectsTamperedCiphertext1344 exercises explicit rejection for Frodo-1344.
+func TestDecapsRejectsTamperedCiphertext1344(t *testing.T) {
+	testDecapsRejectsTamperedCiphertext(t, Frodo1344())
+}
+
+// TestEncapsDecapsAgree640 checks that Decaps recovers the shared secret
+// produced by Encaps for Frodo-640.
+func TestEncapsDecapsAgree640(t *testing.T) { testEncapsDecapsAgree(t, Frodo640()) }
+
+// TestEncapsDecapsAgree976 checks that Decaps recovers the shared secret
+// produced by Encaps for Frodo-976.
+func TestEncapsDecapsAgree976(t *testing.T) { testEncapsDecapsAgree(t, Frodo976()) }
+
+// TestEncapsDecapsAgree1344 checks that Decaps recovers the shared secret
+// produced by Encaps for Frodo-1344.
+func TestEncapsDecapsAgree1344(t *testing.T) { testEncapsDecapsAgree(t, Frodo1344()) }
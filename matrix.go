@@ -0,0 +1,203 @@
+package frodo
+
+import "crypto/aes"
+
+// MatrixBackend computes C = A*S + E for a n1×n2 by n2×n3 matrix product,
+// where A, S, E and the returned C are flat, row-major buffers (A has
+// stride n2, S/E/C have stride n3), masking each output entry by q exactly
+// once. The default backend is pureGoMulAddFlat; SetMatrixBackend lets a
+// platform-specific (e.g. amd64/arm64 assembly) implementation replace it.
+type MatrixBackend func(A, S, E []uint16, n1, n2, n3 int, q uint16) []uint16
+
+var matrixBackend MatrixBackend = pureGoMulAddFlat
+
+// SetMatrixBackend overrides the matrix multiply-add implementation used by
+// KeyGen, Enc and Dec. It is a package-level hook rather than a per-Parameters
+// setting since a backend is chosen for the machine, not the parameter set.
+// The C1 = S1*A product in Enc is computed by mulAddMatrixFromSeedRight and
+// does not go through the backend.
+func SetMatrixBackend(backend MatrixBackend) {
+	matrixBackend = backend
+}
+
+// pureGoMulAddFlat is the default, portable MatrixBackend: a plain triple
+// loop over flat buffers that lets the compiler eliminate bounds checks on
+// the inner loop and keeps each row contiguous in cache. The `& q` mask is
+// applied once per output entry instead of once per multiply-accumulate.
+func pureGoMulAddFlat(A, S, E []uint16, n1, n2, n3 int, q uint16) []uint16 {
+
+	C := make([]uint16, n1*n3)
+	for i := 0; i < n1; i++ {
+		aRow := A[i*n2 : i*n2+n2]
+		cRow := C[i*n3 : i*n3+n3]
+		eRow := E[i*n3 : i*n3+n3]
+
+		for k := 0; k < n3; k++ {
+			var sum uint32
+			for j := 0; j < n2; j++ {
+				sum += uint32(aRow[j]) * uint32(S[j*n3+k])
+			}
+			cRow[k] = (uint16(sum) + eRow[k]) & q
+		}
+	}
+
+	return C
+}
+
+// mulAddFlat computes C = A*S + E for flat, row-major n1×n2 by n2×n3
+// matrices via the configured MatrixBackend.
+func (param *Parameters) mulAddFlat(A, S, E []uint16, n1, n2, n3 int) []uint16 {
+	return matrixBackend(A, S, E, n1, n2, n3, param.q)
+}
+
+// flatten copies a n1-by-n2 matrix into a flat, row-major buffer.
+func flatten(M [][]uint16) []uint16 {
+
+	if len(M) == 0 {
+		return nil
+	}
+
+	flat := make([]uint16, 0, len(M)*len(M[0]))
+	for _, row := range M {
+		flat = append(flat, row...)
+	}
+
+	return flat
+}
+
+// unflatten slices a flat, row-major buffer back into a n1-by-n2 matrix.
+func unflatten(flat []uint16, n1, n2 int) [][]uint16 {
+
+	M := make([][]uint16, n1)
+	for i := range M {
+		M[i] = flat[i*n2 : (i+1)*n2]
+	}
+
+	return M
+}
+
+// mulAddMatrices computes C = A*S + E, flattening its operands so the
+// multiply runs over contiguous buffers.
+func (param *Parameters) mulAddMatrices(A, S, E [][]uint16) [][]uint16 {
+
+	n1, n2, n3 := len(A), len(S), len(S[0])
+	C := param.mulAddFlat(flatten(A), flatten(S), flatten(E), n1, n2, n3)
+
+	return unflatten(C, n1, n3)
+}
+
+// mulMatrices computes C = A*S.
+func (param *Parameters) mulMatrices(A, S [][]uint16) [][]uint16 {
+
+	n1, n2, n3 := len(A), len(S), len(S[0])
+	zero := make([]uint16, n1*n3)
+	C := param.mulAddFlat(flatten(A), flatten(S), zero, n1, n2, n3)
+
+	return unflatten(C, n1, n3)
+}
+
+// sumMatrices computes C = A + B elementwise, reduced mod q.
+func (param *Parameters) sumMatrices(A, B [][]uint16) [][]uint16 {
+
+	C := make([][]uint16, len(A))
+	for i := range C {
+		C[i] = make([]uint16, len(A[i]))
+		for j := range C[i] {
+			C[i][j] = (A[i][j] + B[i][j]) & param.q
+		}
+	}
+
+	return C
+}
+
+// subMatrices computes C = A - B elementwise, reduced mod q.
+func (param *Parameters) subMatrices(A, B [][]uint16) [][]uint16 {
+
+	C := make([][]uint16, len(A))
+	for i := range C {
+		C[i] = make([]uint16, len(A[i]))
+		for j := range C[i] {
+			C[i][j] = (A[i][j] - B[i][j]) & param.q
+		}
+	}
+
+	return C
+}
+
+// rowGenerator returns a function producing row i of the public matrix A for
+// seedA (param.no entries wide), performing any one-time setup (e.g.
+// building the AES cipher) once so it isn't repeated for every row.
+func (param *Parameters) rowGenerator(seedA []byte) (func(i int) []uint16, error) {
+
+	if !param.useAES {
+		return func(i int) []uint16 { return param.genShakeRow(seedA, i) }, nil
+	}
+
+	block, err := aes.NewCipher(seedA)
+	if err != nil {
+		return nil, err
+	}
+
+	return func(i int) []uint16 { return genAESRow(block, param.no, param.q, i) }, nil
+}
+
+// mulAddMatrixFromSeedLeft computes A(seedA)*S + E, where A is the no-by-no
+// public matrix, without ever materializing more than one row of A at a
+// time: each row is regenerated from seedA (via SHAKE or AES, per
+// param.useAES) on demand, used to produce the matching output row, and
+// then discarded.
+func (param *Parameters) mulAddMatrixFromSeedLeft(seedA []byte, S, E [][]uint16) ([][]uint16, error) {
+
+	genRow, err := param.rowGenerator(seedA)
+	if err != nil {
+		return nil, err
+	}
+
+	no, n3 := param.no, len(S[0])
+	flatS, flatE := flatten(S), flatten(E)
+
+	C := make([][]uint16, no)
+	for i := 0; i < no; i++ {
+		eRow := flatE[i*n3 : (i+1)*n3]
+		C[i] = param.mulAddFlat(genRow(i), flatS, eRow, 1, no, n3)
+	}
+
+	return C, nil
+}
+
+// mulAddMatrixFromSeedRight computes X*A(seedA) + E, where A is the no-by-no
+// public matrix, without ever materializing more than one row of A at a
+// time: row j of A contributes X[:,j]*A[j,:] to every output row, so rows
+// are streamed and accumulated into the (small) m-by-no output instead of
+// building the full no-by-no matrix up front.
+func (param *Parameters) mulAddMatrixFromSeedRight(X [][]uint16, seedA []byte, E [][]uint16) ([][]uint16, error) {
+
+	genRow, err := param.rowGenerator(seedA)
+	if err != nil {
+		return nil, err
+	}
+
+	m, no := len(X), param.no
+	acc := make([]uint32, m*no)
+
+	for j := 0; j < no; j++ {
+		aRow := genRow(j)
+		for i := 0; i < m; i++ {
+			x := uint32(X[i][j])
+			out := acc[i*no : (i+1)*no]
+			for k := 0; k < no; k++ {
+				out[k] += x * uint32(aRow[k])
+			}
+		}
+	}
+
+	C := make([][]uint16, m)
+	for i := range C {
+		C[i] = make([]uint16, no)
+		for k := 0; k < no; k++ {
+			C[i][k] = (uint16(acc[i*no+k]) + E[i][k]) & param.q
+		}
+	}
+
+	return C, nil
+}
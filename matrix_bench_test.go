@@ -0,0 +1,22 @@
+package frodo
+
+import "testing"
+
+func benchmarkKeyGen(b *testing.B, param *Parameters) {
+
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		if _, _, err := param.KeyGen(); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+// BenchmarkKeyGen640 measures KeyGen for Frodo-640.
+func BenchmarkKeyGen640(b *testing.B) { benchmarkKeyGen(b, Frodo640()) }
+
+// BenchmarkKeyGen976 measures KeyGen for Frodo-976.
+func BenchmarkKeyGen976(b *testing.B) { benchmarkKeyGen(b, Frodo976()) }
+
+// BenchmarkKeyGen1344 measures KeyGen for Frodo-1344.
+func BenchmarkKeyGen1344(b *testing.B) { benchmarkKeyGen(b, Frodo1344()) }
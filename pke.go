@@ -0,0 +1,153 @@
+package frodo
+
+import (
+	"crypto/rand"
+)
+
+// PKE interface
+type PKE interface {
+	KeyGen() (pk *PublicKey, sk *SecretKey, err error)      // key pair generation
+	Enc(message []byte, pk *PublicKey) (*CipherText, error) // return encrypted messages
+	Dec(cipher *CipherText, sk *SecretKey) []byte           // return decrypted with sekret key cihertext
+}
+
+// PublicKey internal structure
+type PublicKey struct {
+	seedA []byte      // uniform string
+	B     [][]uint16  // matrix є Zq
+	param *Parameters // parameter set this key was generated under, used for (un)marshaling
+}
+
+// SecretKey internal structure
+type SecretKey struct {
+	S     [][]uint16  // matrix є Zq
+	s     []byte      // per-key secret used for explicit rejection in Decaps
+	pkh   []byte      // pkh = SHAKE(pk), cached so Decaps need not re-hash pk
+	pk    *PublicKey  // the matching public key, needed to re-encrypt in Decaps
+	param *Parameters // parameter set this key was generated under, used for (un)marshaling
+}
+
+// CipherText internal structure
+type CipherText struct {
+	C1, C2 [][]uint16
+	param  *Parameters // parameter set this ciphertext was produced under, used for (un)marshaling
+}
+
+// pkBytes returns the canonical byte encoding seedA‖Pack(B) of pk, used both
+// for hashing (pkh = SHAKE(pkBytes)) and as the basis of its serialized form.
+func (param *Parameters) pkBytes(pk *PublicKey) []byte {
+
+	b := make([]byte, 0, len(pk.seedA)+param.D*param.no*param.n/8)
+	b = append(b, pk.seedA...)
+	b = append(b, param.Pack(pk.B)...)
+
+	return b
+}
+
+// KeyGen genere key pairs for chosen parameters
+func (param *Parameters) KeyGen() (pk *PublicKey, sk *SecretKey, err error) {
+
+	pk, sk = new(PublicKey), new(SecretKey)
+	pk.param, sk.param = param, param
+	rLen := param.no * param.n * param.lenX / 4
+	pk.seedA = make([]byte, param.lseedA/8)
+	seedSE, r := make([]byte, (param.lseedSE/8)+1), make([]byte, rLen)
+
+	if _, err = rand.Read(pk.seedA); err != nil {
+		return nil, nil, err
+	}
+
+	seedSE[0] = 0x5F
+	if _, err = rand.Read(seedSE[1:]); err != nil {
+		return nil, nil, err
+	}
+
+	r = param.shake(seedSE, rLen)
+
+	rLen /= 2
+	r1, r2 := make([]byte, rLen), make([]byte, rLen)
+	for i := range r1 {
+		r1[i] = r[i]
+		r2[i] = r[rLen+i]
+	}
+
+	sk.S = param.SampleMatrix(r1, param.no, param.n)
+	E := param.SampleMatrix(r2, param.no, param.n)
+	pk.B, err = param.mulAddMatrixFromSeedLeft(pk.seedA, sk.S, E) // B = A*S + E, A streamed row-by-row from seedA
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// s and pkh are only needed by the CCA KEM layer (Decaps), but every
+	// SecretKey is populated with them here so Decaps never sees a half-built
+	// key regardless of which constructor produced it.
+	sk.pk = pk
+	sk.s = make([]byte, param.lens/8)
+	if _, err = rand.Read(sk.s); err != nil {
+		return nil, nil, err
+	}
+	sk.pkh = param.shake(param.pkBytes(pk), param.lenpkh/8)
+
+	return pk, sk, nil
+}
+
+// Enc encrypts message for chosen parameters length
+func (param *Parameters) Enc(message []byte, pk *PublicKey) (*CipherText, error) {
+
+	seedSE := make([]byte, (param.lseedSE/8)+1)
+	seedSE[0] = 0x96
+	if _, err := rand.Read(seedSE[1:]); err != nil {
+		return nil, err
+	}
+
+	return param.encWithSeed(message, pk, seedSE)
+}
+
+// encWithSeed deterministically encrypts message under pk using the given
+// seedSE (its first byte is the domain-separation prefix, as produced by
+// Enc or Encaps), so the FO transform in Encaps/Decaps can re-derive and
+// compare ciphertexts.
+func (param *Parameters) encWithSeed(message []byte, pk *PublicKey, seedSE []byte) (*CipherText, error) {
+
+	mn := param.n * param.m
+	r := param.shake(seedSE, ((2*param.m*param.no+mn)*param.lenX)/8)
+
+	rLen := param.m * param.no * param.lenX / 8
+	r1, r2, r3 := make([]byte, rLen), make([]byte, rLen), make([]byte, mn*param.lenX/8)
+	for i := range r1 {
+		r1[i] = r[i]
+		r2[i] = r[rLen+i]
+	}
+	rLen += rLen
+	for i := range r3 {
+		r3[i] = r[rLen+i]
+	}
+
+	S1 := param.SampleMatrix(r1, param.m, param.no)
+	E1 := param.SampleMatrix(r2, param.m, param.no)
+	E2 := param.SampleMatrix(r3, param.m, param.n)
+	V := param.mulAddMatrices(S1, pk.B, E2)
+
+	M := param.Encode(message)
+
+	C1, err := param.mulAddMatrixFromSeedRight(S1, pk.seedA, E1) // C1 = S1*A + E1, A streamed row-by-row from seedA
+	if err != nil {
+		return nil, err
+	}
+
+	cipher := new(CipherText)
+	cipher.param = param
+	cipher.C1 = C1
+	cipher.C2 = param.sumMatrices(V, M) // C2 = V + M = S1*B + E2 + M = S1*A*S + S1*E + E2 + M
+
+	return cipher, nil
+}
+
+// Dec return decrypted with secret key cihertext
+// with error S1*E + E2 − E1*S.
+func (param *Parameters) Dec(cipher *CipherText, sk *SecretKey) []byte {
+
+	M := param.subMatrices(cipher.C2, param.mulMatrices(cipher.C1, sk.S)) // M = C2 - C1*S = Enc(message) + S1*E + E2 - E1*S
+	message := param.Decode(M)
+	return message
+}